@@ -0,0 +1,150 @@
+// Package binding validates http.Request form values against a parsed qform.Model and converts
+// them into a typed result, turning the field attributes the DSL already captures (required,
+// maxlength, pattern, min/max, step, type) into validation rules instead of only HTML hints.
+package binding
+
+import (
+	"errors"
+	"math"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/adamjonr/qform"
+)
+
+// FieldError describes a single field that failed validation.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// Bind validates values against model's declared field attributes and returns the submitted
+// values converted to their Go types (a float64 for an InputType "number" value that parses as a
+// number, a []string of the selected values for a checkbox, the raw string otherwise) along
+// with any validation errors. A non-nil error indicates model itself was invalid, not that
+// validation failed; validation failures are reported via the returned []FieldError.
+func Bind(model *qform.Model, values url.Values) (map[string]any, []FieldError, error) {
+	if model == nil {
+		return nil, nil, errors.New("binding error: a model is required")
+	}
+
+	result := make(map[string]any)
+	var fieldErrors []FieldError
+
+	bindFields(model.Fields, values, result, &fieldErrors)
+
+	return result, fieldErrors, nil
+}
+
+func bindFields(fields []qform.Field, values url.Values, result map[string]any, fieldErrors *[]FieldError) {
+	for _, field := range fields {
+		// a group has no value of its own; recurse into its nested fields
+		if field.InputType == "group" || field.InputType == "fieldset" {
+			bindFields(field.Fields, values, result, fieldErrors)
+			continue
+		}
+		// submit buttons and unnamed fields have nothing to bind
+		if field.InputType == "submit" || field.Name == "" {
+			continue
+		}
+		if field.InputType == "checkbox" {
+			selected := values[field.Name]
+			result[field.Name] = selected
+			if _, required := field.Attributes["required"]; required && len(selected) == 0 {
+				*fieldErrors = append(*fieldErrors, FieldError{Field: field.Name, Message: labelFor(field) + " is required"})
+			}
+			continue
+		}
+
+		raw := values.Get(field.Name)
+		if message := validate(field, raw); message != "" {
+			*fieldErrors = append(*fieldErrors, FieldError{Field: field.Name, Message: message})
+		}
+		result[field.Name] = convert(field, raw)
+	}
+}
+
+func validate(field qform.Field, raw string) string {
+	if _, required := field.Attributes["required"]; required && strings.TrimSpace(raw) == "" {
+		return labelFor(field) + " is required"
+	}
+	if raw == "" {
+		return ""
+	}
+	if maxlength, ok := field.Attributes["maxlength"]; ok {
+		if n, err := strconv.Atoi(maxlength); err == nil && len(raw) > n {
+			return labelFor(field) + " must be at most " + maxlength + " characters"
+		}
+	}
+	if minlength, ok := field.Attributes["minlength"]; ok {
+		if n, err := strconv.Atoi(minlength); err == nil && len(raw) < n {
+			return labelFor(field) + " must be at least " + minlength + " characters"
+		}
+	}
+	if pattern, ok := field.Attributes["pattern"]; ok {
+		if re, err := regexp.Compile("^(?:" + pattern + ")$"); err == nil && !re.MatchString(raw) {
+			return labelFor(field) + " is not in the correct format"
+		}
+	}
+	switch field.InputType {
+	case "email":
+		if !strings.Contains(raw, "@") {
+			return labelFor(field) + " must be a valid email address"
+		}
+	case "url":
+		if _, err := url.ParseRequestURI(raw); err != nil {
+			return labelFor(field) + " must be a valid URL"
+		}
+	case "number":
+		return validateNumber(field, raw)
+	}
+	return ""
+}
+
+func validateNumber(field qform.Field, raw string) string {
+	n, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return labelFor(field) + " must be a number"
+	}
+	if min, ok := field.Attributes["min"]; ok {
+		if minValue, err := strconv.ParseFloat(min, 64); err == nil && n < minValue {
+			return labelFor(field) + " must be at least " + min
+		}
+	}
+	if max, ok := field.Attributes["max"]; ok {
+		if maxValue, err := strconv.ParseFloat(max, 64); err == nil && n > maxValue {
+			return labelFor(field) + " must be at most " + max
+		}
+	}
+	if step, ok := field.Attributes["step"]; ok {
+		if stepValue, err := strconv.ParseFloat(step, 64); err == nil && stepValue > 0 {
+			// per the HTML5 spec, steps are measured from min (default 0), not from zero
+			base := 0.0
+			if min, ok := field.Attributes["min"]; ok {
+				base, _ = strconv.ParseFloat(min, 64)
+			}
+			if remainder := math.Mod(n-base, stepValue); remainder > 1e-9 && stepValue-remainder > 1e-9 {
+				return labelFor(field) + " must be a multiple of " + step
+			}
+		}
+	}
+	return ""
+}
+
+func convert(field qform.Field, raw string) any {
+	if field.InputType == "number" {
+		if n, err := strconv.ParseFloat(raw, 64); err == nil {
+			return n
+		}
+	}
+	return raw
+}
+
+func labelFor(field qform.Field) string {
+	if field.Label != "" {
+		return field.Label
+	}
+	return field.Name
+}
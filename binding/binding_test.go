@@ -0,0 +1,144 @@
+package binding
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/adamjonr/qform"
+)
+
+func hasFieldError(errs []FieldError, field string) bool {
+	for _, err := range errs {
+		if err.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+func TestBindRequired(t *testing.T) {
+	model := &qform.Model{Fields: []qform.Field{
+		{Name: "name", InputType: "text", Attributes: map[string]string{"required": "required"}},
+	}}
+
+	_, errs, err := Bind(model, url.Values{"name": {""}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !hasFieldError(errs, "name") {
+		t.Fatalf("expected a required error for name, got %v", errs)
+	}
+
+	_, errs, err = Bind(model, url.Values{"name": {"Ada"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if hasFieldError(errs, "name") {
+		t.Fatalf("did not expect an error for name, got %v", errs)
+	}
+}
+
+func TestBindNumberRange(t *testing.T) {
+	model := &qform.Model{Fields: []qform.Field{
+		{Name: "age", InputType: "number", Attributes: map[string]string{"min": "18", "max": "120"}},
+	}}
+
+	cases := []struct {
+		value   string
+		wantErr bool
+	}{
+		{"17", true},
+		{"18", false},
+		{"120", false},
+		{"121", true},
+		{"not-a-number", true},
+	}
+	for _, c := range cases {
+		result, errs, err := Bind(model, url.Values{"age": {c.value}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if hasFieldError(errs, "age") != c.wantErr {
+			t.Errorf("age=%q: wantErr=%v, got errs=%v", c.value, c.wantErr, errs)
+		}
+		if !c.wantErr && result["age"] == nil {
+			t.Errorf("age=%q: expected a converted value in the result", c.value)
+		}
+	}
+}
+
+// TestBindNumberStep covers the step/min interaction: a step is measured from min (default 0),
+// per the HTML5 spec, not from zero.
+func TestBindNumberStep(t *testing.T) {
+	model := &qform.Model{Fields: []qform.Field{
+		{Name: "qty", InputType: "number", Attributes: map[string]string{"min": "3", "step": "5"}},
+	}}
+
+	cases := []struct {
+		value   string
+		wantErr bool
+	}{
+		{"3", false},
+		{"8", false},
+		{"13", false},
+		{"9", true},
+		{"4", true},
+	}
+	for _, c := range cases {
+		_, errs, err := Bind(model, url.Values{"qty": {c.value}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if hasFieldError(errs, "qty") != c.wantErr {
+			t.Errorf("qty=%q: wantErr=%v, got errs=%v", c.value, c.wantErr, errs)
+		}
+	}
+}
+
+func TestBindEmailAndURL(t *testing.T) {
+	model := &qform.Model{Fields: []qform.Field{
+		{Name: "email", InputType: "email"},
+		{Name: "site", InputType: "url"},
+	}}
+
+	_, errs, err := Bind(model, url.Values{"email": {"not-an-email"}, "site": {"::not a url"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !hasFieldError(errs, "email") {
+		t.Errorf("expected an error for email, got %v", errs)
+	}
+	if !hasFieldError(errs, "site") {
+		t.Errorf("expected an error for site, got %v", errs)
+	}
+
+	_, errs, err = Bind(model, url.Values{"email": {"ada@example.com"}, "site": {"https://example.com"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(errs) != 0 {
+		t.Errorf("expected no errors, got %v", errs)
+	}
+}
+
+func TestBindGroupRecursesIntoNestedFields(t *testing.T) {
+	model := &qform.Model{Fields: []qform.Field{
+		{InputType: "group", Fields: []qform.Field{
+			{Name: "street", InputType: "text", Attributes: map[string]string{"required": "required"}},
+		}},
+	}}
+
+	_, errs, err := Bind(model, url.Values{"street": {""}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !hasFieldError(errs, "street") {
+		t.Fatalf("expected a required error for the nested street field, got %v", errs)
+	}
+}
+
+func TestBindNilModel(t *testing.T) {
+	if _, _, err := Bind(nil, url.Values{}); err == nil {
+		t.Fatal("expected an error for a nil model")
+	}
+}
@@ -0,0 +1,146 @@
+package qform
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+// GenerateJSONSchema converts model into a JSON Schema document describing the shape of the
+// form's submission payload: each Field.Name becomes a property, InputType maps to the schema
+// type, and attributes like required, min/max, maxlength, and pattern map to the corresponding
+// schema keywords. This lets the same qform source drive both the user-facing HTML and a
+// machine-readable contract for a backend API. model is typically the result of DSL.Parse, though
+// a hand-built *Model works too.
+func (*DSL) GenerateJSONSchema(any interface{}) (string, error) {
+	model, ok := any.(*Model)
+	if !ok {
+		return "", errors.New("fastForms error: the appropriate model was not passed into the GenerateJSONSchema function")
+	}
+	encoded, err := json.MarshalIndent(formSchema(model), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// GenerateOpenAPIRequestBody converts model into an OpenAPI 3 requestBody object, using the same
+// schema GenerateJSONSchema produces, suitable for pasting into an OpenAPI document.
+func (*DSL) GenerateOpenAPIRequestBody(any interface{}) (string, error) {
+	model, ok := any.(*Model)
+	if !ok {
+		return "", errors.New("fastForms error: the appropriate model was not passed into the GenerateOpenAPIRequestBody function")
+	}
+	requestBody := map[string]interface{}{
+		"required": true,
+		"content": map[string]interface{}{
+			"application/x-www-form-urlencoded": map[string]interface{}{
+				"schema": formSchema(model),
+			},
+		},
+	}
+	encoded, err := json.MarshalIndent(requestBody, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+func formSchema(model *Model) map[string]interface{} {
+	properties := map[string]interface{}{}
+	var required []string
+	collectSchemaProperties(model.Fields, properties, &required)
+	schema := map[string]interface{}{
+		"$schema":    "http://json-schema.org/draft-07/schema#",
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// collectSchemaProperties walks fields, recursing into groups, since a group is only a visual
+// section and its children still submit as properties of the same payload.
+func collectSchemaProperties(fields []Field, properties map[string]interface{}, required *[]string) {
+	for _, field := range fields {
+		if field.InputType == "group" || field.InputType == "fieldset" {
+			collectSchemaProperties(field.Fields, properties, required)
+			continue
+		}
+		if field.InputType == "submit" || field.Name == "" {
+			continue
+		}
+		properties[field.Name] = fieldSchema(field)
+		if _, ok := field.Attributes["required"]; ok {
+			*required = append(*required, field.Name)
+		}
+	}
+}
+
+func fieldSchema(field Field) map[string]interface{} {
+	property := map[string]interface{}{}
+
+	switch field.InputType {
+	case "number":
+		property["type"] = "number"
+	case "checkbox":
+		if len(field.Options) > 0 {
+			property["type"] = "array"
+			property["items"] = map[string]interface{}{"type": "string", "enum": sortedOptionNames(field.Options)}
+		} else {
+			property["type"] = "boolean"
+		}
+	case "radio", "select":
+		property["type"] = "string"
+		if len(field.Options) > 0 {
+			property["enum"] = sortedOptionNames(field.Options)
+		}
+	case "email":
+		property["type"] = "string"
+		property["format"] = "email"
+	case "url":
+		property["type"] = "string"
+		property["format"] = "uri"
+	case "date":
+		property["type"] = "string"
+		property["format"] = "date"
+	default:
+		property["type"] = "string"
+	}
+
+	if maxlength, ok := field.Attributes["maxlength"]; ok {
+		if n, err := strconv.Atoi(maxlength); err == nil {
+			property["maxLength"] = n
+		}
+	}
+	if minlength, ok := field.Attributes["minlength"]; ok {
+		if n, err := strconv.Atoi(minlength); err == nil {
+			property["minLength"] = n
+		}
+	}
+	if pattern, ok := field.Attributes["pattern"]; ok {
+		property["pattern"] = pattern
+	}
+	if min, ok := field.Attributes["min"]; ok {
+		if n, err := strconv.ParseFloat(min, 64); err == nil {
+			property["minimum"] = n
+		}
+	}
+	if max, ok := field.Attributes["max"]; ok {
+		if n, err := strconv.ParseFloat(max, 64); err == nil {
+			property["maximum"] = n
+		}
+	}
+	if step, ok := field.Attributes["step"]; ok {
+		if n, err := strconv.ParseFloat(step, 64); err == nil {
+			property["multipleOf"] = n
+		}
+	}
+	if field.Label != "" {
+		property["title"] = field.Label
+	}
+
+	return property
+}
@@ -0,0 +1,88 @@
+package qform
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func representativeModel() *Model {
+	return &Model{Fields: []Field{
+		{Name: "email", InputType: "email", Attributes: map[string]string{"required": "required"}},
+		{Name: "age", InputType: "number", Attributes: map[string]string{"min": "18", "max": "120", "step": "1"}},
+		{Name: "bio", InputType: "textarea", Attributes: map[string]string{"maxlength": "500"}},
+		{Name: "plan", InputType: "select", Attributes: map[string]string{}, Options: map[string]Option{
+			"free": {Value: "Free"}, "pro": {Value: "Pro"},
+		}},
+		{InputType: "group", Fields: []Field{
+			{Name: "newsletter", InputType: "checkbox", Attributes: map[string]string{}},
+		}},
+	}}
+}
+
+func TestGenerateJSONSchema(t *testing.T) {
+	encoded, err := (&DSL{}).GenerateJSONSchema(representativeModel())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var schema map[string]interface{}
+	if err := json.Unmarshal([]byte(encoded), &schema); err != nil {
+		t.Fatalf("expected valid JSON, got error %v; output:\n%s", err, encoded)
+	}
+
+	properties, ok := schema["properties"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected a properties object, got %+v", schema)
+	}
+	for _, name := range []string{"email", "age", "bio", "plan", "newsletter"} {
+		if _, ok := properties[name]; !ok {
+			t.Errorf("expected a %q property (newsletter should be pulled up out of its group), got %+v", name, properties)
+		}
+	}
+
+	age, ok := properties["age"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected age to be an object, got %+v", properties["age"])
+	}
+	if age["minimum"] != 18.0 || age["maximum"] != 120.0 || age["multipleOf"] != 1.0 {
+		t.Errorf("expected age's min/max/step to map to minimum/maximum/multipleOf, got %+v", age)
+	}
+
+	required, ok := schema["required"].([]interface{})
+	if !ok || len(required) != 1 || required[0] != "email" {
+		t.Errorf("expected only email to be required, got %+v", schema["required"])
+	}
+}
+
+func TestGenerateOpenAPIRequestBody(t *testing.T) {
+	encoded, err := (&DSL{}).GenerateOpenAPIRequestBody(representativeModel())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var requestBody map[string]interface{}
+	if err := json.Unmarshal([]byte(encoded), &requestBody); err != nil {
+		t.Fatalf("expected valid JSON, got error %v; output:\n%s", err, encoded)
+	}
+
+	if requestBody["required"] != true {
+		t.Errorf("expected the requestBody itself to be required, got %+v", requestBody["required"])
+	}
+	content, ok := requestBody["content"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected a content object, got %+v", requestBody)
+	}
+	urlencoded, ok := content["application/x-www-form-urlencoded"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected an application/x-www-form-urlencoded entry, got %+v", content)
+	}
+	if _, ok := urlencoded["schema"].(map[string]interface{}); !ok {
+		t.Fatalf("expected a schema object, got %+v", urlencoded)
+	}
+}
+
+func TestGenerateJSONSchemaRejectsWrongType(t *testing.T) {
+	if _, err := (&DSL{}).GenerateJSONSchema("not a model"); err == nil {
+		t.Fatal("expected an error for a non-*Model argument")
+	}
+}
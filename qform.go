@@ -3,27 +3,38 @@ package qform
 import (
 	"bytes"
 	"errors"
-	"strconv"
 	"strings"
 
 	"github.com/adamjonr/dialects"
 )
 
-// DSL struct provides the definition of the dialect
-type DSL struct{}
+// DSL struct provides the definition of the dialect. It stashes the *Model built by the most
+// recent NewModel call so Parse can hand it back to the caller; a zero-value DSL is still safe to
+// pass directly to dialects.Parse, which calls NewModel itself before using the Dialectable.
+type DSL struct {
+	model *Model
+}
 
 type Attribute struct {
 	Name  string
 	Value string
 }
 
+// Option is one choice in a radio/checkbox/select options list. HasOther marks an
+// "other, please specify" choice that pairs with a freeform text input.
+type Option struct {
+	Value    string
+	HasOther bool
+}
+
 type Field struct {
 	Name       string
 	Label      string
 	InputType  string
 	ID         string
 	Attributes map[string]string
-	Options    map[string]string
+	Options    map[string]Option
+	Fields     []Field
 }
 
 type Model struct {
@@ -31,6 +42,40 @@ type Model struct {
 	Fields     []Field
 }
 
+// RenderContext carries previously submitted values and validation errors so a form can be
+// re-rendered with user input retained and error messages shown inline, e.g. after a failed
+// qform/binding.Bind call.
+type RenderContext struct {
+	Values map[string][]string
+	Errors map[string]string
+}
+
+func (ctx *RenderContext) value(name string) string {
+	if ctx == nil || len(ctx.Values[name]) == 0 {
+		return ""
+	}
+	return ctx.Values[name][0]
+}
+
+func (ctx *RenderContext) hasValue(name, value string) bool {
+	if ctx == nil {
+		return false
+	}
+	for _, v := range ctx.Values[name] {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (ctx *RenderContext) fieldError(name string) string {
+	if ctx == nil {
+		return ""
+	}
+	return ctx.Errors[name]
+}
+
 const Indent = "  "
 
 // NewDialect returns the FastFormsDialect struct for parsing of input
@@ -77,6 +122,21 @@ select
   tech Tech Support
   receivables
 ]`,
+			"Grouped Fields": `group
+- legend Personal Info
+  text
+  - name first_name
+  - required
+  text
+  - name last_name
+  - required
+
+group
+- legend Shipping Address
+  text
+  - name street
+  text
+  - name city`,
 		},
 		PartDefinitions: map[string]dialects.PartDefinition{
 			"form": {
@@ -103,8 +163,8 @@ select
 				},
 			},
 			"form field": {
-				Description:  " Composed of optional new-line, field type, and zero-or-more field attributes.",
-				Constituents: [][]string{{"newline?", "field type", "field attribute*"}},
+				Description:  " Composed of optional new-line, field type, zero-or-more field attributes, and zero-or-more nested fields.",
+				Constituents: [][]string{{"newline?", "field type", "field attribute*", "nested field*"}},
 				Handler: func(part *dialects.Part, any interface{}) (ok bool) {
 					model, ok := any.(*Model)
 					if !ok {
@@ -114,12 +174,17 @@ select
 					field := Field{
 						InputType:  part.Constituents[0].Constituents[0].Value,
 						Attributes: make(map[string]string),
-						Options:    make(map[string]string),
+						Options:    make(map[string]Option),
 					}
-					// cycle through the field attributes
+					// cycle through the field attributes and nested fields
 					for i, length := 1, len(part.Constituents); i < length; i = i + 1 {
 						// store constituent
 						constituent := part.Constituents[i]
+						// a group's children are parsed as nested fields, indented one level in
+						if constituent.Name == "nested field" {
+							field.Fields = append(field.Fields, parseNestedField(constituent))
+							continue
+						}
 						// check attribute type
 						if constituent.Constituents[0].Name == "name" {
 							// handle standard attribute
@@ -132,7 +197,7 @@ select
 								value = constituent.Constituents[1].Value
 							}
 							// store as label if named label
-							if name == "label" {
+							if name == "label" || name == "legend" {
 								field.Label = value
 								continue
 							}
@@ -158,8 +223,14 @@ select
 								if len(option.Constituents) > 1 {
 									value = option.Constituents[1].Value
 								}
-								// add attribute
-								field.Options[name] = value
+								// a trailing "+" (or the literal name "other") marks an "other, please specify" option
+								hasOther := name == "other"
+								if strings.HasSuffix(name, "+") {
+									name = strings.TrimSuffix(name, "+")
+									hasOther = true
+								}
+								// add option
+								field.Options[name] = Option{Value: value, HasOther: hasOther}
 							}
 						}
 					}
@@ -178,6 +249,16 @@ select
 			"field attribute": {
 				Constituents: [][]string{{"hyphen", "name", "value?", "newline?"}, {"hyphen", "array", "newline?"}},
 			},
+			"nested field": {
+				Description:  "A field indented one level (two spaces) inside a group, making it a child of that group.",
+				Constituents: [][]string{{"nested field type", "nested field attribute*"}},
+			},
+			"nested field type": {
+				Constituents: [][]string{{"indent", "field name", "newline"}},
+			},
+			"nested field attribute": {
+				Constituents: [][]string{{"indent", "hyphen", "name", "value?", "newline?"}, {"indent", "hyphen", "array", "newline?"}},
+			},
 			"array": {
 				Constituents: [][]string{{"array open", "newline", "option*", "array close"}},
 			},
@@ -193,7 +274,7 @@ select
 				Ignore: true,
 			},
 			"name": {
-				Regex: `^([a-zA-Z0-9_\.-]+)([ ])?`, // grab up to and including first space
+				Regex: `^([a-zA-Z0-9_\.+-]+)([ ])?`, // grab up to and including first space; trailing "+" marks an "other" option
 				FormatMatch: func(matches []string) string {
 					return matches[1]
 				},
@@ -218,12 +299,97 @@ select
 	return dialect
 }
 
-func (*DSL) NewModel() interface{} {
+// parseNestedField builds a Field from a "nested field" part, the indented fields that make up a group's children.
+func parseNestedField(part *dialects.Part) Field {
+	field := Field{
+		InputType:  part.Constituents[0].Constituents[0].Value,
+		Attributes: make(map[string]string),
+		Options:    make(map[string]Option),
+	}
+	for i, length := 1, len(part.Constituents); i < length; i = i + 1 {
+		constituent := part.Constituents[i]
+		if constituent.Constituents[0].Name == "name" {
+			name := constituent.Constituents[0].Value
+			value := name
+			if len(constituent.Constituents) > 1 {
+				value = constituent.Constituents[1].Value
+			}
+			if name == "label" || name == "legend" {
+				field.Label = value
+				continue
+			}
+			if name == "id" {
+				field.ID = value
+			}
+			if name == "name" {
+				field.Name = value
+			}
+			field.Attributes[name] = value
+		} else {
+			options := constituent.Constituents[0].Constituents
+			for _, option := range options {
+				name := option.Constituents[0].Value
+				value := strings.ToUpper(name[0:1]) + name[1:]
+				if len(option.Constituents) > 1 {
+					value = option.Constituents[1].Value
+				}
+				hasOther := name == "other"
+				if strings.HasSuffix(name, "+") {
+					name = strings.TrimSuffix(name, "+")
+					hasOther = true
+				}
+				field.Options[name] = Option{Value: value, HasOther: hasOther}
+			}
+		}
+	}
+	return field
+}
+
+func (dsl *DSL) NewModel() interface{} {
 	model := &Model{}
+	dsl.model = model
 	return model
 }
 
+// Parse parses source against the Fast Forms grammar and returns the resulting *Model, so callers
+// can feed it to binding.Bind, GenerateJSONSchema, or a custom Renderer instead of building a
+// Model by hand. dialects.Parse itself only returns the rendered HTML string, so this wraps it and
+// recovers the Model that NewModel stashed on dsl along the way.
+func (dsl *DSL) Parse(source string) (*Model, error) {
+	if _, err, _ := dialects.Parse(dsl, source); err != nil {
+		return nil, err
+	}
+	return dsl.model, nil
+}
+
+// GenerateOutput renders model as HTML using the default BootstrapRenderer, preserving the
+// original <div class="form-group"> markup.
 func (*DSL) GenerateOutput(any interface{}) (string, error) {
+	return renderModel(any, &BootstrapRenderer{}, nil)
+}
+
+// GenerateOutputWithContext renders the form the same way GenerateOutput does, but re-fills
+// fields from ctx.Values and shows ctx.Errors inline next to the fields they belong to. Use it
+// to re-display a form after a failed qform/binding.Bind call.
+func (*DSL) GenerateOutputWithContext(any interface{}, ctx *RenderContext) (string, error) {
+	return renderModel(any, &BootstrapRenderer{}, ctx)
+}
+
+// GenerateOutputWith renders model using renderer instead of the default BootstrapRenderer, for
+// callers who want a different markup convention but don't need to re-fill submitted values.
+func (*DSL) GenerateOutputWith(any interface{}, renderer Renderer) (string, error) {
+	return renderModel(any, renderer, nil)
+}
+
+// GenerateOutputWithRenderer combines GenerateOutputWith and GenerateOutputWithContext: it renders
+// model using renderer and also re-fills fields from ctx.Values and shows ctx.Errors inline, so a
+// PlainRenderer or TemplateRenderer caller can re-display a form after a failed
+// qform/binding.Bind call, the same way the default BootstrapRenderer already can.
+func (*DSL) GenerateOutputWithRenderer(any interface{}, renderer Renderer, ctx *RenderContext) (string, error) {
+	return renderModel(any, renderer, ctx)
+}
+
+func renderModel(any interface{}, renderer Renderer, ctx *RenderContext) (string, error) {
 	// ensure we have a Model
 	model, ok := any.(*Model)
 	if !ok {
@@ -238,128 +404,17 @@ func (*DSL) GenerateOutput(any interface{}) (string, error) {
 	// render close of form tag
 	output.WriteString(">\n")
 	// render fields
-	renderFormFields(model.Fields, output)
+	if err := renderFields(output, model.Fields, renderer, ctx); err != nil {
+		return "", err
+	}
 	// render closing form tag
 	output.WriteString("</form>\n")
 	// return the final string
 	return output.String(), nil
 }
 
-func capitalize(input string) string {
-	return strings.ToUpper(input[0:1]) + input[1:]
-}
-
-func renderFieldLabel(field Field, output *bytes.Buffer) {
-	if field.Label != "" {
-		output.WriteString(Indent + Indent + "<label for=\"" + field.ID + "\">" + field.Label + "</label>\n")
-	} else {
-		if field.InputType != "submit" {
-			output.WriteString(Indent + Indent + "<label for=\"" + field.ID + "\">" + capitalize(field.Name) + "</label>\n")
-		}
-	}
-}
-
 func renderFormAttributes(attributes []Attribute, output *bytes.Buffer) {
 	for _, attribute := range attributes {
 		output.WriteString(" " + attribute.Name + "=\"" + attribute.Value + "\"")
 	}
 }
-
-func renderFormFields(fields []Field, output *bytes.Buffer) {
-	for i, field := range fields {
-		// output opening tag
-		output.WriteString(Indent + "<div class=\"form-group\">\n")
-		// ensure each field has id and name
-		if field.Name == "" {
-			field.Name = "field" + strconv.Itoa(i+1)
-			field.Attributes["name"] = field.Name
-		}
-		if field.ID == "" {
-			field.ID = field.Name
-			field.Attributes["id"] = field.Name
-		}
-		// handle field-type-specific output
-		switch field.InputType {
-		case "textarea":
-			renderFieldLabel(field, output)
-			renderTextarea(field, output)
-		case "select":
-			renderFieldLabel(field, output)
-			renderSelect(field, output)
-		case "radio":
-			renderRadio(field, output)
-		case "checkbox":
-			renderCheckbox(field, output)
-		default:
-			renderFieldLabel(field, output)
-			renderInput(field, output)
-		}
-		// output closing tag
-		output.WriteString(Indent + "</div>\n")
-	}
-}
-
-func renderInput(field Field, output *bytes.Buffer) {
-	output.WriteString(Indent + Indent + "<input type=\"" + field.InputType + "\"")
-
-	for name, value := range field.Attributes {
-		output.WriteString(" " + name + "=\"" + value + "\"")
-	}
-
-	output.WriteString(" />\n")
-}
-func renderTextarea(field Field, output *bytes.Buffer) {
-	output.WriteString(Indent + Indent + "<textarea")
-
-	for name, value := range field.Attributes {
-		output.WriteString(" " + name + "=\"" + value + "\"")
-	}
-
-	output.WriteString("></textarea>\n")
-}
-func renderSelect(field Field, output *bytes.Buffer) {
-	output.WriteString(Indent + Indent + "<select")
-
-	for name, value := range field.Attributes {
-		output.WriteString(" " + name + "=\"" + value + "\"")
-	}
-
-	output.WriteString(">\n")
-
-	for name, value := range field.Options {
-		output.WriteString(Indent + Indent + Indent + "<option value=\"" + name + "\">" + value + "</option>\n")
-	}
-
-	output.WriteString(Indent + Indent + "</select>\n")
-}
-func renderRadio(field Field, output *bytes.Buffer) {
-	for name, value := range field.Options {
-		output.WriteString(Indent + Indent + "<label><input type=\"radio\"")
-		count := 0
-		for attrName, attrValue := range field.Attributes {
-			count = count + 1
-			if attrName == "id" && count > 1 {
-				continue
-			}
-
-			output.WriteString(" " + attrName + "=\"" + attrValue + "\"")
-		}
-
-		output.WriteString(" value=\"" + name + "\"/>" + value + "</label>\n")
-	}
-}
-func renderCheckbox(field Field, output *bytes.Buffer) {
-	for name, value := range field.Options {
-		output.WriteString(Indent + Indent + "<label><input type=\"checkbox\"")
-		count := 0
-		for attrName, attrValue := range field.Attributes {
-			count = count + 1
-			if attrName == "id" && count > 1 {
-				continue
-			}
-
-			output.WriteString(" " + attrName + "=\"" + attrValue + "\"")
-		}
-		output.WriteString(" value=\"" + name + "\"/>" + value + "</label>\n")
-	}
-}
@@ -0,0 +1,120 @@
+package qform
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseGroupNesting(t *testing.T) {
+	source := `group
+- legend Personal Info
+  text
+  - name first_name
+  - required
+  text
+  - name last_name
+`
+	dsl := &DSL{}
+	model, err := dsl.Parse(source)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(model.Fields) != 1 {
+		t.Fatalf("expected 1 top-level field, got %d", len(model.Fields))
+	}
+
+	group := model.Fields[0]
+	if group.InputType != "group" {
+		t.Fatalf("got InputType %q, want %q", group.InputType, "group")
+	}
+	if group.Label != "Personal Info" {
+		t.Fatalf("got Label %q, want %q", group.Label, "Personal Info")
+	}
+	if len(group.Fields) != 2 {
+		t.Fatalf("expected 2 nested fields, got %d", len(group.Fields))
+	}
+	if group.Fields[0].Name != "first_name" || group.Fields[1].Name != "last_name" {
+		t.Fatalf("unexpected nested field names: %+v", group.Fields)
+	}
+	if _, ok := group.Fields[0].Attributes["required"]; !ok {
+		t.Fatalf("expected first_name to carry its required attribute, got %+v", group.Fields[0].Attributes)
+	}
+}
+
+func TestParseOtherOptionMarker(t *testing.T) {
+	source := `radio
+- name preference
+- [
+  call Call me back
+  other+ Something else
+]
+`
+	dsl := &DSL{}
+	model, err := dsl.Parse(source)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(model.Fields) != 1 {
+		t.Fatalf("expected 1 field, got %d", len(model.Fields))
+	}
+
+	field := model.Fields[0]
+	call, ok := field.Options["call"]
+	if !ok || call.HasOther {
+		t.Fatalf("expected a plain \"call\" option, got %+v", field.Options)
+	}
+	other, ok := field.Options["other"]
+	if !ok {
+		t.Fatalf("expected the trailing \"+\" to be stripped, leaving an \"other\" option, got %+v", field.Options)
+	}
+	if !other.HasOther {
+		t.Fatalf("expected the \"other+\" option to be marked HasOther, got %+v", other)
+	}
+	if other.Value != "Something else" {
+		t.Fatalf("got option value %q, want %q", other.Value, "Something else")
+	}
+}
+
+func TestGenerateOutputRendersOtherInput(t *testing.T) {
+	model := &Model{Fields: []Field{
+		{Name: "preference", InputType: "radio", Attributes: map[string]string{}, Options: map[string]Option{
+			"call":  {Value: "Call me back"},
+			"other": {Value: "Something else", HasOther: true},
+		}},
+	}}
+
+	dsl := &DSL{}
+	output, err := dsl.GenerateOutput(model)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output, `name="preference_other"`) {
+		t.Fatalf("expected a paired freeform input for the \"other\" option, got:\n%s", output)
+	}
+	if strings.Contains(strings.Replace(output, `name="preference_other"`, "", 1), `name="preference_other"`) {
+		t.Fatalf("expected the freeform input to appear exactly once, got:\n%s", output)
+	}
+}
+
+func TestGenerateOutputRendersNestedGroup(t *testing.T) {
+	model := &Model{Fields: []Field{
+		{InputType: "group", Label: "Shipping Address", Fields: []Field{
+			{Name: "street", InputType: "text", Attributes: map[string]string{}},
+		}},
+	}}
+
+	dsl := &DSL{}
+	output, err := dsl.GenerateOutput(model)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output, "<fieldset>") || !strings.Contains(output, "</fieldset>") {
+		t.Fatalf("expected the group to render as a fieldset, got:\n%s", output)
+	}
+	if !strings.Contains(output, "<legend>Shipping Address</legend>") {
+		t.Fatalf("expected the group's legend, got:\n%s", output)
+	}
+	if !strings.Contains(output, `id="street"`) {
+		t.Fatalf("expected the nested field to render inside the group, got:\n%s", output)
+	}
+}
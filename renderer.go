@@ -0,0 +1,466 @@
+package qform
+
+import (
+	"errors"
+	"html"
+	"html/template"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Renderer renders each qform field type to HTML. Built-in implementations are BootstrapRenderer
+// (the original <div class="form-group"> markup), PlainRenderer (bare elements, no wrapper), and
+// TemplateRenderer (user-supplied templates for Tailwind, PicoCSS, or any other component
+// system). Each method is responsible for its own wrapper, label, and inline error markup.
+type Renderer interface {
+	RenderInput(w io.Writer, field Field, ctx *RenderContext) error
+	RenderTextarea(w io.Writer, field Field, ctx *RenderContext) error
+	RenderSelect(w io.Writer, field Field, ctx *RenderContext) error
+	RenderRadio(w io.Writer, field Field, ctx *RenderContext) error
+	RenderCheckbox(w io.Writer, field Field, ctx *RenderContext) error
+	RenderGroupOpen(w io.Writer, field Field) error
+	RenderGroupClose(w io.Writer) error
+}
+
+// renderFields walks fields, recursing into groups, and dispatches each leaf field to the
+// matching Renderer method. It also assigns the same default name/id fields get when unset.
+func renderFields(w io.Writer, fields []Field, renderer Renderer, ctx *RenderContext) error {
+	for i, field := range fields {
+		// groups recurse into their own nested fields rather than being dispatched as a leaf
+		if field.InputType == "group" || field.InputType == "fieldset" {
+			if err := renderer.RenderGroupOpen(w, field); err != nil {
+				return err
+			}
+			if err := renderFields(w, field.Fields, renderer, ctx); err != nil {
+				return err
+			}
+			if err := renderer.RenderGroupClose(w); err != nil {
+				return err
+			}
+			continue
+		}
+		// ensure each field has id and name
+		if field.Name == "" {
+			field.Name = "field" + strconv.Itoa(i+1)
+			field.Attributes["name"] = field.Name
+		}
+		if field.ID == "" {
+			field.ID = field.Name
+			field.Attributes["id"] = field.Name
+		}
+		var err error
+		switch field.InputType {
+		case "textarea":
+			err = renderer.RenderTextarea(w, field, ctx)
+		case "select":
+			err = renderer.RenderSelect(w, field, ctx)
+		case "radio":
+			err = renderer.RenderRadio(w, field, ctx)
+		case "checkbox":
+			err = renderer.RenderCheckbox(w, field, ctx)
+		default:
+			err = renderer.RenderInput(w, field, ctx)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func capitalize(input string) string {
+	return strings.ToUpper(input[0:1]) + input[1:]
+}
+
+func sortedAttributeNames(attributes map[string]string) []string {
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedOptionNames(options map[string]Option) []string {
+	names := make([]string, 0, len(options))
+	for name := range options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func writeAttributes(w io.Writer, attributes map[string]string) {
+	for _, name := range sortedAttributeNames(attributes) {
+		io.WriteString(w, " "+name+"=\""+attributes[name]+"\"")
+	}
+}
+
+// writeInputAttributes writes field's attributes for an <input> or <textarea>, skipping the
+// field's own literal "value" attribute when ctx supplies a submitted value for it (so the two
+// don't both get written), and returns that submitted value, still unescaped, for the caller to
+// write into the element itself.
+func writeInputAttributes(w io.Writer, field Field, ctx *RenderContext) (submittedValue string, hasSubmittedValue bool) {
+	submittedValue = ctx.value(field.Name)
+	hasSubmittedValue = submittedValue != ""
+	for _, name := range sortedAttributeNames(field.Attributes) {
+		if name == "value" && hasSubmittedValue {
+			continue
+		}
+		io.WriteString(w, " "+name+"=\""+field.Attributes[name]+"\"")
+	}
+	return submittedValue, hasSubmittedValue
+}
+
+func writeFieldLabel(w io.Writer, field Field) {
+	if field.Label != "" {
+		io.WriteString(w, Indent+Indent+"<label for=\""+field.ID+"\">"+field.Label+"</label>\n")
+	} else if field.InputType != "submit" {
+		io.WriteString(w, Indent+Indent+"<label for=\""+field.ID+"\">"+capitalize(field.Name)+"</label>\n")
+	}
+}
+
+func writeFieldError(w io.Writer, field Field, ctx *RenderContext) {
+	if message := ctx.fieldError(field.Name); message != "" {
+		io.WriteString(w, Indent+Indent+"<div class=\"invalid-feedback\">"+message+"</div>\n")
+	}
+}
+
+func writeOtherInput(w io.Writer, field Field, option Option) {
+	if !option.HasOther {
+		return
+	}
+	io.WriteString(w, Indent+Indent+"<input type=\"text\" name=\""+field.Name+"_other\" id=\""+field.ID+"_other\" />\n")
+}
+
+// writeSingleCheckbox renders a checkbox field with no declared options (e.g. one inferred from
+// a Go bool by structform) as a single checkbox, rather than as an options list.
+func writeSingleCheckbox(w io.Writer, field Field, ctx *RenderContext) {
+	writeFieldLabel(w, field)
+	io.WriteString(w, Indent+Indent+"<input type=\"checkbox\"")
+	writeAttributes(w, field.Attributes)
+	value := field.Attributes["value"]
+	if value == "" {
+		value = "on"
+	}
+	if ctx.hasValue(field.Name, value) {
+		io.WriteString(w, " checked")
+	}
+	io.WriteString(w, " />\n")
+}
+
+// BootstrapRenderer is the default Renderer, preserving qform's original
+// <div class="form-group"> wrapping and plain HTML5 elements.
+type BootstrapRenderer struct{}
+
+func (*BootstrapRenderer) RenderGroupOpen(w io.Writer, field Field) error {
+	io.WriteString(w, Indent+"<fieldset>\n")
+	if field.Label != "" {
+		io.WriteString(w, Indent+Indent+"<legend>"+field.Label+"</legend>\n")
+	}
+	return nil
+}
+
+func (*BootstrapRenderer) RenderGroupClose(w io.Writer) error {
+	io.WriteString(w, Indent+"</fieldset>\n")
+	return nil
+}
+
+func (*BootstrapRenderer) RenderInput(w io.Writer, field Field, ctx *RenderContext) error {
+	io.WriteString(w, Indent+"<div class=\"form-group\">\n")
+	writeFieldLabel(w, field)
+	io.WriteString(w, Indent+Indent+"<input type=\""+field.InputType+"\"")
+	if value, ok := writeInputAttributes(w, field, ctx); ok {
+		io.WriteString(w, " value=\""+html.EscapeString(value)+"\"")
+	}
+	io.WriteString(w, " />\n")
+	writeFieldError(w, field, ctx)
+	io.WriteString(w, Indent+"</div>\n")
+	return nil
+}
+
+func (*BootstrapRenderer) RenderTextarea(w io.Writer, field Field, ctx *RenderContext) error {
+	io.WriteString(w, Indent+"<div class=\"form-group\">\n")
+	writeFieldLabel(w, field)
+	io.WriteString(w, Indent+Indent+"<textarea")
+	writeAttributes(w, field.Attributes)
+	io.WriteString(w, ">"+html.EscapeString(ctx.value(field.Name))+"</textarea>\n")
+	writeFieldError(w, field, ctx)
+	io.WriteString(w, Indent+"</div>\n")
+	return nil
+}
+
+func (*BootstrapRenderer) RenderSelect(w io.Writer, field Field, ctx *RenderContext) error {
+	io.WriteString(w, Indent+"<div class=\"form-group\">\n")
+	writeFieldLabel(w, field)
+	io.WriteString(w, Indent+Indent+"<select")
+	writeAttributes(w, field.Attributes)
+	io.WriteString(w, ">\n")
+	for _, name := range sortedOptionNames(field.Options) {
+		option := field.Options[name]
+		io.WriteString(w, Indent+Indent+Indent+"<option value=\""+name+"\"")
+		if ctx.value(field.Name) == name {
+			io.WriteString(w, " selected")
+		}
+		io.WriteString(w, ">"+option.Value+"</option>\n")
+	}
+	io.WriteString(w, Indent+Indent+"</select>\n")
+	writeFieldError(w, field, ctx)
+	io.WriteString(w, Indent+"</div>\n")
+	return nil
+}
+
+func (*BootstrapRenderer) RenderRadio(w io.Writer, field Field, ctx *RenderContext) error {
+	io.WriteString(w, Indent+"<div class=\"form-group\">\n")
+	for _, name := range sortedOptionNames(field.Options) {
+		option := field.Options[name]
+		io.WriteString(w, Indent+Indent+"<label><input type=\"radio\"")
+		writeChoiceAttributes(w, field.Attributes)
+		if ctx.value(field.Name) == name {
+			io.WriteString(w, " checked")
+		}
+		io.WriteString(w, " value=\""+name+"\"/>"+option.Value+"</label>\n")
+		writeOtherInput(w, field, option)
+	}
+	writeFieldError(w, field, ctx)
+	io.WriteString(w, Indent+"</div>\n")
+	return nil
+}
+
+func (*BootstrapRenderer) RenderCheckbox(w io.Writer, field Field, ctx *RenderContext) error {
+	io.WriteString(w, Indent+"<div class=\"form-group\">\n")
+	if len(field.Options) == 0 {
+		writeSingleCheckbox(w, field, ctx)
+	}
+	for _, name := range sortedOptionNames(field.Options) {
+		option := field.Options[name]
+		io.WriteString(w, Indent+Indent+"<label><input type=\"checkbox\"")
+		writeChoiceAttributes(w, field.Attributes)
+		if ctx.hasValue(field.Name, name) {
+			io.WriteString(w, " checked")
+		}
+		io.WriteString(w, " value=\""+name+"\"/>"+option.Value+"</label>\n")
+		writeOtherInput(w, field, option)
+	}
+	writeFieldError(w, field, ctx)
+	io.WriteString(w, Indent+"</div>\n")
+	return nil
+}
+
+// writeChoiceAttributes writes a radio/checkbox field's attributes, in name order, for one
+// option's <input>. "id" is written only when it is the first attribute in that order; the
+// count restarts on every call, so such an id is repeated on every option's <input>.
+func writeChoiceAttributes(w io.Writer, attributes map[string]string) {
+	count := 0
+	for _, name := range sortedAttributeNames(attributes) {
+		count = count + 1
+		if name == "id" && count > 1 {
+			continue
+		}
+		io.WriteString(w, " "+name+"=\""+attributes[name]+"\"")
+	}
+}
+
+// PlainRenderer emits bare HTML5 elements with no wrapping div, classes, or framework markup.
+type PlainRenderer struct{}
+
+func (*PlainRenderer) RenderGroupOpen(w io.Writer, field Field) error {
+	io.WriteString(w, "<fieldset>\n")
+	if field.Label != "" {
+		io.WriteString(w, "<legend>"+field.Label+"</legend>\n")
+	}
+	return nil
+}
+
+func (*PlainRenderer) RenderGroupClose(w io.Writer) error {
+	io.WriteString(w, "</fieldset>\n")
+	return nil
+}
+
+func (*PlainRenderer) RenderInput(w io.Writer, field Field, ctx *RenderContext) error {
+	writeFieldLabel(w, field)
+	io.WriteString(w, "<input type=\""+field.InputType+"\"")
+	if value, ok := writeInputAttributes(w, field, ctx); ok {
+		io.WriteString(w, " value=\""+html.EscapeString(value)+"\"")
+	}
+	io.WriteString(w, " />\n")
+	writeFieldError(w, field, ctx)
+	return nil
+}
+
+func (*PlainRenderer) RenderTextarea(w io.Writer, field Field, ctx *RenderContext) error {
+	writeFieldLabel(w, field)
+	io.WriteString(w, "<textarea")
+	writeAttributes(w, field.Attributes)
+	io.WriteString(w, ">"+html.EscapeString(ctx.value(field.Name))+"</textarea>\n")
+	writeFieldError(w, field, ctx)
+	return nil
+}
+
+func (*PlainRenderer) RenderSelect(w io.Writer, field Field, ctx *RenderContext) error {
+	writeFieldLabel(w, field)
+	io.WriteString(w, "<select")
+	writeAttributes(w, field.Attributes)
+	io.WriteString(w, ">\n")
+	for _, name := range sortedOptionNames(field.Options) {
+		option := field.Options[name]
+		io.WriteString(w, "<option value=\""+name+"\"")
+		if ctx.value(field.Name) == name {
+			io.WriteString(w, " selected")
+		}
+		io.WriteString(w, ">"+option.Value+"</option>\n")
+	}
+	io.WriteString(w, "</select>\n")
+	writeFieldError(w, field, ctx)
+	return nil
+}
+
+func (*PlainRenderer) RenderRadio(w io.Writer, field Field, ctx *RenderContext) error {
+	for _, name := range sortedOptionNames(field.Options) {
+		option := field.Options[name]
+		io.WriteString(w, "<label><input type=\"radio\"")
+		writeChoiceAttributes(w, field.Attributes)
+		if ctx.value(field.Name) == name {
+			io.WriteString(w, " checked")
+		}
+		io.WriteString(w, " value=\""+name+"\"/>"+option.Value+"</label>\n")
+		writeOtherInput(w, field, option)
+	}
+	writeFieldError(w, field, ctx)
+	return nil
+}
+
+func (*PlainRenderer) RenderCheckbox(w io.Writer, field Field, ctx *RenderContext) error {
+	if len(field.Options) == 0 {
+		writeSingleCheckbox(w, field, ctx)
+	}
+	for _, name := range sortedOptionNames(field.Options) {
+		option := field.Options[name]
+		io.WriteString(w, "<label><input type=\"checkbox\"")
+		writeChoiceAttributes(w, field.Attributes)
+		if ctx.hasValue(field.Name, name) {
+			io.WriteString(w, " checked")
+		}
+		io.WriteString(w, " value=\""+name+"\"/>"+option.Value+"</label>\n")
+		writeOtherInput(w, field, option)
+	}
+	writeFieldError(w, field, ctx)
+	return nil
+}
+
+// templateFieldData is the value each TemplateRenderer template executes against. Attributes and
+// Options are exposed as slices, sorted by name, since Go's map iteration order is nondeterministic.
+type templateFieldData struct {
+	Field   Field
+	Value   string
+	Values  []string
+	Error   string
+	Attrs   []Attribute
+	Options []templateOption
+}
+
+type templateOption struct {
+	Name  string
+	Value string
+}
+
+// TemplateRenderer delegates rendering to a *template.Template with named templates "input",
+// "textarea", "select", "radio", "checkbox", and "field-wrapper", so the exact markup for each
+// field type is defined by the caller's own templates rather than hardcoded in qform.
+type TemplateRenderer struct {
+	Templates *template.Template
+}
+
+func (r *TemplateRenderer) fieldData(field Field, ctx *RenderContext) templateFieldData {
+	attrs := make([]Attribute, 0, len(field.Attributes))
+	for _, name := range sortedAttributeNames(field.Attributes) {
+		attrs = append(attrs, Attribute{Name: name, Value: field.Attributes[name]})
+	}
+	options := make([]templateOption, 0, len(field.Options))
+	for _, name := range sortedOptionNames(field.Options) {
+		options = append(options, templateOption{Name: name, Value: field.Options[name].Value})
+	}
+	var values []string
+	if ctx != nil {
+		values = ctx.Values[field.Name]
+	}
+	return templateFieldData{
+		Field:   field,
+		Value:   ctx.value(field.Name),
+		Values:  values,
+		Error:   ctx.fieldError(field.Name),
+		Attrs:   attrs,
+		Options: options,
+	}
+}
+
+func (r *TemplateRenderer) render(w io.Writer, name string, field Field, ctx *RenderContext) error {
+	fieldHTML := new(strings.Builder)
+	if err := r.Templates.ExecuteTemplate(fieldHTML, name, r.fieldData(field, ctx)); err != nil {
+		return err
+	}
+	if wrapper := r.Templates.Lookup("field-wrapper"); wrapper != nil {
+		return wrapper.Execute(w, struct {
+			Field Field
+			Error string
+			HTML  template.HTML
+		}{Field: field, Error: ctx.fieldError(field.Name), HTML: template.HTML(fieldHTML.String())})
+	}
+	_, err := io.WriteString(w, fieldHTML.String())
+	return err
+}
+
+func (r *TemplateRenderer) RenderInput(w io.Writer, field Field, ctx *RenderContext) error {
+	return r.render(w, "input", field, ctx)
+}
+
+func (r *TemplateRenderer) RenderTextarea(w io.Writer, field Field, ctx *RenderContext) error {
+	return r.render(w, "textarea", field, ctx)
+}
+
+func (r *TemplateRenderer) RenderSelect(w io.Writer, field Field, ctx *RenderContext) error {
+	return r.render(w, "select", field, ctx)
+}
+
+func (r *TemplateRenderer) RenderRadio(w io.Writer, field Field, ctx *RenderContext) error {
+	return r.render(w, "radio", field, ctx)
+}
+
+func (r *TemplateRenderer) RenderCheckbox(w io.Writer, field Field, ctx *RenderContext) error {
+	return r.render(w, "checkbox", field, ctx)
+}
+
+func (r *TemplateRenderer) RenderGroupOpen(w io.Writer, field Field) error {
+	if tmpl := r.Templates.Lookup("group-open"); tmpl != nil {
+		return tmpl.Execute(w, field)
+	}
+	io.WriteString(w, "<fieldset>\n")
+	if field.Label != "" {
+		io.WriteString(w, "<legend>"+field.Label+"</legend>\n")
+	}
+	return nil
+}
+
+func (r *TemplateRenderer) RenderGroupClose(w io.Writer) error {
+	if tmpl := r.Templates.Lookup("group-close"); tmpl != nil {
+		return tmpl.Execute(w, nil)
+	}
+	io.WriteString(w, "</fieldset>\n")
+	return nil
+}
+
+var errMissingTemplate = errors.New("qform error: TemplateRenderer requires \"input\", \"textarea\", \"select\", \"radio\", and \"checkbox\" templates")
+
+// NewTemplateRenderer wraps templates in a TemplateRenderer, after checking that it defines the
+// "input", "textarea", "select", "radio", and "checkbox" templates every field type needs. The
+// "field-wrapper", "group-open", and "group-close" templates are optional.
+func NewTemplateRenderer(templates *template.Template) (*TemplateRenderer, error) {
+	for _, name := range []string{"input", "textarea", "select", "radio", "checkbox"} {
+		if templates.Lookup(name) == nil {
+			return nil, errMissingTemplate
+		}
+	}
+	return &TemplateRenderer{Templates: templates}, nil
+}
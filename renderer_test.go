@@ -0,0 +1,113 @@
+package qform
+
+import (
+	"html/template"
+	"strings"
+	"testing"
+)
+
+func fieldWith(name, inputType string) Field {
+	return Field{Name: name, InputType: inputType, Attributes: map[string]string{}}
+}
+
+func TestBootstrapRendererFieldTypes(t *testing.T) {
+	ctx := &RenderContext{
+		Values: map[string][]string{"bio": {"hello"}, "color": {"blue"}, "agree": {"yes"}},
+		Errors: map[string]string{"bio": "bio is required"},
+	}
+	fields := []Field{
+		fieldWith("name", "text"),
+		fieldWith("bio", "textarea"),
+		{Name: "color", InputType: "select", Attributes: map[string]string{}, Options: map[string]Option{
+			"blue": {Value: "Blue"}, "red": {Value: "Red"},
+		}},
+		{Name: "agree", InputType: "radio", Attributes: map[string]string{}, Options: map[string]Option{
+			"yes": {Value: "Yes"}, "no": {Value: "No"},
+		}},
+		{Name: "toppings", InputType: "checkbox", Attributes: map[string]string{}, Options: map[string]Option{
+			"cheese": {Value: "Cheese"},
+		}},
+	}
+
+	output, err := (&DSL{}).GenerateOutputWithContext(&Model{Fields: fields}, ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, want := range []string{
+		`<input type="text"`,
+		`<textarea`,
+		">hello</textarea>",
+		`<div class="invalid-feedback">bio is required</div>`,
+		`<select`,
+		`<option value="blue" selected>Blue</option>`,
+		`<input type="radio"`,
+		`value="yes"/>Yes`,
+		` checked`,
+		`<input type="checkbox"`,
+		`value="cheese"/>Cheese`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestPlainRendererOmitsWrapperMarkup(t *testing.T) {
+	fields := []Field{fieldWith("name", "text")}
+	output, err := (&DSL{}).GenerateOutputWith(&Model{Fields: fields}, &PlainRenderer{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(output, "form-group") {
+		t.Fatalf("expected PlainRenderer to omit the Bootstrap wrapper, got:\n%s", output)
+	}
+	if !strings.Contains(output, `<input type="text"`) {
+		t.Fatalf("expected the input to still render, got:\n%s", output)
+	}
+}
+
+func TestGenerateOutputWithRendererAppliesBothRendererAndContext(t *testing.T) {
+	fields := []Field{fieldWith("bio", "textarea")}
+	ctx := &RenderContext{Values: map[string][]string{"bio": {"hi there"}}}
+
+	output, err := (&DSL{}).GenerateOutputWithRenderer(&Model{Fields: fields}, &PlainRenderer{}, ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(output, "form-group") {
+		t.Fatalf("expected PlainRenderer's bare markup, got:\n%s", output)
+	}
+	if !strings.Contains(output, ">hi there</textarea>") {
+		t.Fatalf("expected the submitted value to be re-filled, got:\n%s", output)
+	}
+}
+
+func TestTemplateRendererUsesCallerTemplates(t *testing.T) {
+	templates := template.Must(template.New("input").Parse(`<my-input name="{{.Field.Name}}" value="{{.Value}}" />`))
+	template.Must(templates.New("textarea").Parse(`<my-textarea>{{.Value}}</my-textarea>`))
+	template.Must(templates.New("select").Parse(`<my-select></my-select>`))
+	template.Must(templates.New("radio").Parse(`<my-radio></my-radio>`))
+	template.Must(templates.New("checkbox").Parse(`<my-checkbox></my-checkbox>`))
+
+	renderer, err := NewTemplateRenderer(templates)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := &RenderContext{Values: map[string][]string{"name": {"Ada"}}}
+	output, err := (&DSL{}).GenerateOutputWithRenderer(&Model{Fields: []Field{fieldWith("name", "text")}}, renderer, ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output, `<my-input name="name" value="Ada" />`) {
+		t.Fatalf("expected the caller's own template markup, got:\n%s", output)
+	}
+}
+
+func TestNewTemplateRendererRequiresCoreTemplates(t *testing.T) {
+	templates := template.Must(template.New("input").Parse(`<my-input />`))
+	if _, err := NewTemplateRenderer(templates); err != errMissingTemplate {
+		t.Fatalf("got error %v, want %v", err, errMissingTemplate)
+	}
+}
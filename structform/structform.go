@@ -0,0 +1,193 @@
+// Package structform builds a *qform.Model from a Go struct via reflection, giving Go users a
+// code-first path to qform forms that reuses the same renderers without writing the DSL by hand.
+package structform
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/adamjonr/qform"
+)
+
+// FromStruct walks v using reflection, reading "form" struct tags such as
+// `form:"name=email,type=email,required,maxlength=80,label=Your Email"` and
+// `form:"type=select,options=us:United States|ca:Canada"`, and builds a *qform.Model. When a
+// field has no explicit type=, it's inferred from the Go type: string -> text, bool -> checkbox,
+// int/float -> number, time.Time -> date. Nested structs become fieldset groups.
+func FromStruct(v interface{}) (*qform.Model, error) {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil, fmt.Errorf("structform error: %s is a nil pointer", value.Type())
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("structform error: %s is not a struct", value.Type())
+	}
+
+	fields, err := fieldsFromStruct(value)
+	if err != nil {
+		return nil, err
+	}
+	return &qform.Model{Fields: fields}, nil
+}
+
+func fieldsFromStruct(value reflect.Value) ([]qform.Field, error) {
+	structType := value.Type()
+	var fields []qform.Field
+
+	for i := 0; i < structType.NumField(); i++ {
+		structField := structType.Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+		tag := structField.Tag.Get("form")
+		if tag == "-" {
+			continue
+		}
+
+		fieldValue := value.Field(i)
+		for fieldValue.Kind() == reflect.Ptr {
+			if fieldValue.IsNil() {
+				fieldValue = reflect.Zero(fieldValue.Type().Elem())
+				break
+			}
+			fieldValue = fieldValue.Elem()
+		}
+
+		// a nested struct (other than time.Time, which renders as a date input) becomes a group,
+		// unless the tag force-types it to something else, in which case that override wins and
+		// the struct is treated as an opaque field rather than recursed into.
+		isNestedStruct := fieldValue.Kind() == reflect.Struct && fieldValue.Type() != reflect.TypeOf(time.Time{})
+		if isNestedStruct && !hasTypeOverride(tag) {
+			group, err := groupFromStructField(structField, fieldValue, tag)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, group)
+			continue
+		}
+
+		fields = append(fields, fieldFromStructField(structField, fieldValue, tag))
+	}
+
+	return fields, nil
+}
+
+// hasTypeOverride reports whether tag declares an explicit type= other than group/fieldset,
+// which should keep a nested struct field from being recursed into as a group.
+func hasTypeOverride(tag string) bool {
+	inputType := parseTag(tag)["type"]
+	return inputType != "" && inputType != "group" && inputType != "fieldset"
+}
+
+func groupFromStructField(structField reflect.StructField, fieldValue reflect.Value, tag string) (qform.Field, error) {
+	nested, err := fieldsFromStruct(fieldValue)
+	if err != nil {
+		return qform.Field{}, err
+	}
+	attrs := parseTag(tag)
+	legend := attrs["legend"]
+	if legend == "" {
+		legend = attrs["label"]
+	}
+	if legend == "" {
+		legend = structField.Name
+	}
+	return qform.Field{InputType: "group", Label: legend, Fields: nested}, nil
+}
+
+func fieldFromStructField(structField reflect.StructField, fieldValue reflect.Value, tag string) qform.Field {
+	attrs := parseTag(tag)
+
+	name := attrs["name"]
+	if name == "" {
+		name = strings.ToLower(structField.Name)
+	}
+
+	inputType := attrs["type"]
+	if inputType == "" {
+		inputType = inferInputType(fieldValue)
+	}
+
+	field := qform.Field{
+		Name:       name,
+		Label:      attrs["label"],
+		InputType:  inputType,
+		Attributes: map[string]string{"name": name},
+		Options:    make(map[string]qform.Option),
+	}
+
+	if options, ok := attrs["options"]; ok {
+		field.Options = parseOptions(options)
+	}
+
+	for key, value := range attrs {
+		switch key {
+		case "name", "type", "label", "options":
+			continue
+		}
+		field.Attributes[key] = value
+	}
+
+	return field
+}
+
+// inferInputType picks an HTML input type from fieldValue's Go type when the form tag doesn't
+// specify type= explicitly.
+func inferInputType(fieldValue reflect.Value) string {
+	if fieldValue.Type() == reflect.TypeOf(time.Time{}) {
+		return "date"
+	}
+	switch fieldValue.Kind() {
+	case reflect.Bool:
+		return "checkbox"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	default:
+		return "text"
+	}
+}
+
+// parseTag splits a form tag into its comma-separated attributes; a bare attribute like
+// "required" is stored with its own name as its value, matching the qform DSL's convention.
+func parseTag(tag string) map[string]string {
+	attrs := make(map[string]string)
+	if tag == "" {
+		return attrs
+	}
+	for _, part := range strings.Split(tag, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if name, value, ok := strings.Cut(part, "="); ok {
+			attrs[name] = value
+		} else {
+			attrs[part] = part
+		}
+	}
+	return attrs
+}
+
+// parseOptions parses an options=us:United States|ca:Canada tag value into qform.Options.
+func parseOptions(spec string) map[string]qform.Option {
+	options := make(map[string]qform.Option)
+	for _, pair := range strings.Split(spec, "|") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(pair, ":")
+		if !ok {
+			value = name
+		}
+		options[name] = qform.Option{Value: value}
+	}
+	return options
+}
@@ -0,0 +1,153 @@
+package structform
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFromStructInfersInputTypes(t *testing.T) {
+	type Signup struct {
+		Name    string
+		Age     int
+		Agree   bool
+		Website string `form:"type=url"`
+	}
+
+	model, err := FromStruct(Signup{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(model.Fields) != 4 {
+		t.Fatalf("expected 4 fields, got %d", len(model.Fields))
+	}
+
+	want := map[string]string{
+		"name":    "text",
+		"age":     "number",
+		"agree":   "checkbox",
+		"website": "url",
+	}
+	for _, field := range model.Fields {
+		expected, ok := want[field.Name]
+		if !ok {
+			t.Fatalf("unexpected field %q in model", field.Name)
+		}
+		if field.InputType != expected {
+			t.Errorf("field %q: got InputType %q, want %q", field.Name, field.InputType, expected)
+		}
+	}
+}
+
+func TestFromStructNestedStructBecomesGroup(t *testing.T) {
+	type Address struct {
+		Street string
+	}
+	type Person struct {
+		Name    string
+		Address Address
+	}
+
+	model, err := FromStruct(Person{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	found := false
+	for _, field := range model.Fields {
+		if field.InputType == "group" {
+			found = true
+			if len(field.Fields) != 1 || field.Fields[0].Name != "street" {
+				t.Fatalf("expected the group to contain a single street field, got %+v", field.Fields)
+			}
+		}
+	}
+	if !found {
+		t.Fatal("expected the nested Address struct to become a group field")
+	}
+}
+
+// TestFromStructNestedStructTypeOverride covers a nested struct field whose tag force-types it
+// away from "group" — the override should win instead of being silently ignored, and the struct
+// should be treated as an opaque field rather than recursed into.
+func TestFromStructNestedStructTypeOverride(t *testing.T) {
+	type Address struct {
+		Street string
+	}
+	type Person struct {
+		Name    string
+		Address Address `form:"type=hidden"`
+	}
+
+	model, err := FromStruct(Person{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	found := false
+	for _, field := range model.Fields {
+		if field.Name == "address" {
+			found = true
+			if field.InputType != "hidden" {
+				t.Errorf("got InputType %q, want %q", field.InputType, "hidden")
+			}
+			if len(field.Fields) != 0 {
+				t.Errorf("expected no nested fields once the type is overridden, got %+v", field.Fields)
+			}
+		}
+	}
+	if !found {
+		t.Fatal("expected the overridden Address field to appear as a single field named \"address\"")
+	}
+}
+
+func TestFromStructTimeTimeBecomesDate(t *testing.T) {
+	type Event struct {
+		StartsAt time.Time
+	}
+
+	model, err := FromStruct(Event{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(model.Fields) != 1 || model.Fields[0].InputType != "date" {
+		t.Fatalf("expected a single date field, got %+v", model.Fields)
+	}
+}
+
+func TestFromStructOptionsTag(t *testing.T) {
+	type Signup struct {
+		Country string `form:"type=select,options=us:United States|ca:Canada"`
+	}
+
+	model, err := FromStruct(Signup{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(model.Fields) != 1 {
+		t.Fatalf("expected 1 field, got %d", len(model.Fields))
+	}
+	field := model.Fields[0]
+	if field.InputType != "select" {
+		t.Fatalf("got InputType %q, want %q", field.InputType, "select")
+	}
+	option, ok := field.Options["us"]
+	if !ok || option.Value != "United States" {
+		t.Fatalf("expected option %q with value %q, got %+v", "us", "United States", field.Options)
+	}
+}
+
+func TestFromStructRejectsNonStruct(t *testing.T) {
+	if _, err := FromStruct(42); err == nil {
+		t.Fatal("expected an error for a non-struct value")
+	}
+}
+
+func TestFromStructRejectsNilPointer(t *testing.T) {
+	type Signup struct {
+		Name string
+	}
+	var signup *Signup
+	if _, err := FromStruct(signup); err == nil {
+		t.Fatal("expected an error for a nil pointer")
+	}
+}